@@ -0,0 +1,108 @@
+package servemux
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// ErrorEncoder inspects err and, if it knows how to handle it, returns the
+// HTTP status code and response body to encode. ok is false if err is not
+// recognized, in which case the next registered ErrorEncoder is tried.
+type ErrorEncoder func(err error) (status int, body any, ok bool)
+
+// ContentTypeNegotiator writes status and body to w, choosing a wire format
+// (e.g. JSON, plain text, proto) based on r, typically its Accept header.
+type ContentTypeNegotiator func(w http.ResponseWriter, r *http.Request, status int, body any) error
+
+// StatusError pairs an error with the HTTP status code it should map to, so
+// it can be resolved automatically by the built-in ErrorEncoder without the
+// caller having to register one.
+type StatusError struct {
+	Status int
+	Err    error
+}
+
+// NewStatusError creates a StatusError that maps err to the given HTTP
+// status code.
+func NewStatusError(status int, err error) *StatusError {
+	return &StatusError{Status: status, Err: err}
+}
+
+// Error implements error.
+func (e *StatusError) Error() string { return e.Err.Error() }
+
+// Unwrap allows errors.Is and errors.As to see through to the wrapped error.
+func (e *StatusError) Unwrap() error { return e.Err }
+
+// RegisterErrorEncoder appends encoder to the chain consulted by Handle when
+// a route returns an error. Encoders are tried in registration order; the
+// first one that returns ok=true wins.
+func (mux *ServeMux) RegisterErrorEncoder(encoder ErrorEncoder) {
+	mux.errorEncoders = append(mux.errorEncoders, encoder)
+}
+
+// OnError registers an ErrorEncoder that matches any error satisfying
+// errors.Is(err, target), mapping it to status with the body produced by
+// encode. This mirrors grpc-gateway's WithErrorHandler pattern, letting
+// callers centralize HTTP status decisions for a given sentinel or type
+// instead of threading status codes through every handler.
+func (mux *ServeMux) OnError(target error, status int, encode func(err error) any) {
+	mux.RegisterErrorEncoder(func(err error) (int, any, bool) {
+		if errors.Is(err, target) {
+			return status, encode(err), true
+		}
+		return 0, nil, false
+	})
+}
+
+// SetContentTypeNegotiator overrides how a matched ErrorEncoder's status and
+// body are written to the response. The default negotiator marshals body as
+// JSON.
+func (mux *ServeMux) SetContentTypeNegotiator(n ContentTypeNegotiator) {
+	if n != nil {
+		mux.negotiator = n
+	}
+}
+
+// statusErrorEncoder is registered by default so *StatusError is mapped
+// automatically, without requiring an explicit RegisterErrorEncoder/OnError
+// call.
+func statusErrorEncoder(err error) (int, any, bool) {
+	var se *StatusError
+	if errors.As(err, &se) {
+		return se.Status, map[string]string{"error": se.Err.Error()}, true
+	}
+	return 0, nil, false
+}
+
+// defaultNegotiator marshals body as JSON and writes it with status.
+func defaultNegotiator(w http.ResponseWriter, r *http.Request, status int, body any) error {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	return json.NewEncoder(w).Encode(body)
+}
+
+// handleError walks the registered ErrorEncoders, writing the response via
+// the configured ContentTypeNegotiator on the first match. If none match, it
+// falls back to the mux's LastResortErrorHandler, and finally to
+// http.Error.
+func (mux *ServeMux) handleError(w http.ResponseWriter, r *http.Request, err error) {
+	for _, encode := range mux.errorEncoders {
+		status, body, ok := encode(err)
+		if !ok {
+			continue
+		}
+		if negErr := mux.negotiator(w, r, status, body); negErr != nil {
+			http.Error(w, negErr.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if mux.lastResort != nil {
+		mux.lastResort(w, r, err)
+		return
+	}
+
+	http.Error(w, err.Error(), http.StatusInternalServerError)
+}
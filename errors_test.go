@@ -0,0 +1,108 @@
+package servemux
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestServeMux_StatusError(t *testing.T) {
+	mux := New()
+	mux.Route(Route{
+		Pattern: "GET /data",
+		Handler: func(w http.ResponseWriter, r *http.Request) error {
+			return NewStatusError(http.StatusBadRequest, errors.New("bad input"))
+		},
+	})
+
+	req := httptest.NewRequest("GET", "/data", nil)
+	res := httptest.NewRecorder()
+	mux.ServeHTTP(res, req)
+
+	expectTrue(t, res.Code == http.StatusBadRequest)
+	expectTrue(t, res.Header().Get("Content-Type") == "application/json; charset=utf-8")
+
+	var body map[string]string
+	err := json.Unmarshal(res.Body.Bytes(), &body)
+	expectTrue(t, err == nil)
+	expectTrue(t, body["error"] == "bad input")
+}
+
+func TestServeMux_OnError(t *testing.T) {
+	errNotFound := errors.New("not found")
+
+	mux := New()
+	mux.OnError(errNotFound, http.StatusNotFound, func(err error) any {
+		return map[string]string{"message": err.Error()}
+	})
+
+	mux.Route(Route{
+		Pattern: "GET /data",
+		Handler: func(w http.ResponseWriter, r *http.Request) error {
+			return errNotFound
+		},
+	})
+
+	req := httptest.NewRequest("GET", "/data", nil)
+	res := httptest.NewRecorder()
+	mux.ServeHTTP(res, req)
+
+	expectTrue(t, res.Code == http.StatusNotFound)
+
+	var body map[string]string
+	err := json.Unmarshal(res.Body.Bytes(), &body)
+	expectTrue(t, err == nil)
+	expectTrue(t, body["message"] == "not found")
+}
+
+func TestServeMux_RegisterErrorEncoder_FallsThroughToLastResort(t *testing.T) {
+	anError := errors.New("an error")
+
+	mux := New()
+	mux.RegisterErrorEncoder(func(err error) (int, any, bool) {
+		return 0, nil, false
+	})
+	mux.SetLastResortErrorHandler(func(w http.ResponseWriter, r *http.Request, err error) {
+		if errors.Is(err, anError) {
+			w.WriteHeader(http.StatusTeapot)
+		}
+	})
+
+	mux.Route(Route{
+		Pattern: "GET /data",
+		Handler: func(w http.ResponseWriter, r *http.Request) error { return anError },
+	})
+
+	req := httptest.NewRequest("GET", "/data", nil)
+	res := httptest.NewRecorder()
+	mux.ServeHTTP(res, req)
+
+	expectTrue(t, res.Code == http.StatusTeapot)
+}
+
+func TestServeMux_SetContentTypeNegotiator(t *testing.T) {
+	mux := New()
+	mux.SetContentTypeNegotiator(func(w http.ResponseWriter, r *http.Request, status int, body any) error {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.WriteHeader(status)
+		_, err := w.Write([]byte(body.(map[string]string)["error"]))
+		return err
+	})
+
+	mux.Route(Route{
+		Pattern: "GET /data",
+		Handler: func(w http.ResponseWriter, r *http.Request) error {
+			return NewStatusError(http.StatusBadRequest, errors.New("bad input"))
+		},
+	})
+
+	req := httptest.NewRequest("GET", "/data", nil)
+	res := httptest.NewRecorder()
+	mux.ServeHTTP(res, req)
+
+	expectTrue(t, res.Code == http.StatusBadRequest)
+	expectTrue(t, res.Header().Get("Content-Type") == "text/plain; charset=utf-8")
+	expectTrue(t, res.Body.String() == "bad input")
+}
@@ -0,0 +1,90 @@
+package servemux
+
+import "strings"
+
+// Group is a child registrar that shares its parent's underlying *http.ServeMux
+// while accumulating its own path prefix and middleware stack. It is the
+// equivalent of gorilla/mux's Router.PathPrefix(...).Subrouter(), letting
+// related routes be declared together without repeating a prefix or a
+// middleware chain on every call.
+type Group struct {
+	mux    *ServeMux
+	prefix string
+	midl   Middleware
+}
+
+// Group creates a new Group rooted at prefix. middlewares are applied, in
+// order, to every route registered under the group (and its subgroups),
+// in addition to the mux's global middlewares.
+func (mux *ServeMux) Group(prefix string, middlewares ...Middleware) *Group {
+	return &Group{
+		mux:    mux,
+		prefix: prefix,
+		midl:   foldMiddlewares(middlewares),
+	}
+}
+
+// Subrouter creates a Group nested under g, inheriting g's prefix and
+// middleware stack and extending both with its own. Groups may be nested to
+// any depth; each level inherits its ancestors' middlewares in declaration
+// order.
+func (g *Group) Subrouter(prefix string, middlewares ...Middleware) *Group {
+	return &Group{
+		mux:    g.mux,
+		prefix: joinPath(g.prefix, prefix),
+		midl:   foldMiddlewares(append([]Middleware{g.midl}, middlewares...)),
+	}
+}
+
+// Route is the Group analog of ServeMux.Route: it prepends the group's prefix
+// to r.Pattern and wraps r.Handler with the group's inherited middlewares
+// followed by middlewares, before delegating to the underlying ServeMux.
+func (g *Group) Route(r Route, middlewares ...Middleware) {
+	chain := foldMiddlewares(append([]Middleware{g.midl}, middlewares...))
+	meta := r
+	meta.Pattern = joinPattern(g.prefix, r.Pattern)
+	g.mux.register(meta, chain.Then(r.Handler), compileMatchers(r))
+}
+
+// HandleFunc just like Handle, but it accepts HandlerFunc.
+func (g *Group) HandleFunc(pattern string, handler HandlerFunc) {
+	g.Handle(pattern, handler)
+}
+
+// Handle registers handler under the group's prefix, wrapped with the
+// group's inherited middlewares, before delegating to the underlying
+// ServeMux.
+func (g *Group) Handle(pattern string, handler Handler) {
+	g.mux.Handle(joinPattern(g.prefix, pattern), g.midl.Then(handler))
+}
+
+// splitPattern splits a Go 1.22 ServeMux pattern into its method and the
+// remainder (host and/or path). For example, "GET /users" becomes
+// ("GET", "/users"), and "/users" becomes ("", "/users").
+func splitPattern(pattern string) (method, rest string) {
+	if i := strings.IndexByte(pattern, ' '); i >= 0 {
+		return pattern[:i], pattern[i+1:]
+	}
+	return "", pattern
+}
+
+// joinPattern prepends prefix to pattern's path while preserving pattern's
+// leading method verb, if any. For example, joinPattern("/api/v1", "GET
+// /users") yields "GET /api/v1/users".
+func joinPattern(prefix, pattern string) string {
+	method, rest := splitPattern(pattern)
+	joined := joinPath(prefix, rest)
+	if method == "" {
+		return joined
+	}
+	return method + " " + joined
+}
+
+// joinPath concatenates two path segments with exactly one slash between
+// them, regardless of whether a already ends with one or b already starts
+// with one. This keeps nested Group/Subrouter prefixes (and prefix+pattern
+// joins) free of the double slashes a naive string concatenation would
+// introduce, e.g. joinPath("/api/", "/v1") yields "/api/v1", not "/api//v1".
+func joinPath(a, b string) string {
+	return strings.TrimSuffix(a, "/") + "/" + strings.TrimPrefix(b, "/")
+}
@@ -0,0 +1,107 @@
+package servemux
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestServeMux_Group(t *testing.T) {
+	trace := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+				w.Header().Add("X-Trace", name+"-started")
+				defer w.Header().Add("X-Trace", name+"-ended")
+				return next.ServeHTTP(w, r)
+			})
+		}
+	}
+
+	mux := New()
+	mux.SetGlobalMiddlewares(trace("global"))
+
+	api := mux.Group("/api", trace("group"))
+	v1 := api.Subrouter("/v1", trace("subgroup"))
+
+	v1.Route(Route{
+		Pattern: "GET /users",
+		Handler: func(w http.ResponseWriter, r *http.Request) error {
+			w.Header().Add("X-Trace", "handler")
+			w.WriteHeader(200)
+			return nil
+		},
+	}, trace("route"))
+
+	t.Run("prefix is concatenated with method preserved", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/v1/users", nil)
+		res := httptest.NewRecorder()
+		mux.ServeHTTP(res, req)
+		expectTrue(t, res.Code == 200)
+	})
+
+	t.Run("middlewares run global -> group -> subgroup -> route", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/v1/users", nil)
+		res := httptest.NewRecorder()
+		mux.ServeHTTP(res, req)
+
+		traces := strings.Join(res.Header().Values("X-Trace"), ",")
+		want := "global-started,group-started,subgroup-started,route-started," +
+			"handler," +
+			"route-ended,subgroup-ended,group-ended,global-ended"
+		expectTrue(t, traces == want)
+	})
+}
+
+func TestServeMux_GroupHandleFunc(t *testing.T) {
+	mux := New()
+	admin := mux.Group("/admin")
+	admin.HandleFunc("GET /health", func(w http.ResponseWriter, r *http.Request) error {
+		w.WriteHeader(200)
+		return nil
+	})
+
+	req := httptest.NewRequest("GET", "/admin/health", nil)
+	res := httptest.NewRecorder()
+	mux.ServeHTTP(res, req)
+	expectTrue(t, res.Code == 200)
+}
+
+func TestJoinPattern(t *testing.T) {
+	cases := []struct {
+		prefix, pattern, want string
+	}{
+		{"/api/v1", "GET /users", "GET /api/v1/users"},
+		{"/api", "/users", "/api/users"},
+		{"", "GET /users", "GET /users"},
+		{"/api/", "GET /users", "GET /api/users"},
+	}
+
+	for _, c := range cases {
+		got := joinPattern(c.prefix, c.pattern)
+		if got != c.want {
+			t.Errorf("joinPattern(%q, %q) = %q, want %q", c.prefix, c.pattern, got, c.want)
+		}
+	}
+}
+
+func TestServeMux_NestedSubrouter_TrailingSlashPrefix(t *testing.T) {
+	mux := New()
+	v1 := mux.Group("/api/").Subrouter("/v1")
+	v1.Route(Route{
+		Pattern: "GET /users",
+		Handler: func(w http.ResponseWriter, r *http.Request) error {
+			w.WriteHeader(200)
+			return nil
+		},
+	})
+
+	routes := mux.Routes()
+	expectTrue(t, len(routes) == 1)
+	expectTrue(t, routes[0].Pattern == "GET /api/v1/users")
+
+	req := httptest.NewRequest("GET", "/api/v1/users", nil)
+	res := httptest.NewRecorder()
+	mux.ServeHTTP(res, req)
+	expectTrue(t, res.Code == 200)
+}
@@ -0,0 +1,50 @@
+package servemux
+
+import "net/http"
+
+// routeAlternative pairs a handler with the matcher predicates that must all
+// pass for it to be selected. Because http.ServeMux dispatches by pattern
+// alone, ServeMux keeps a list of these per pattern and evaluates them in
+// registration order inside a single HandleFunc closure; this is what lets
+// two Routes share a Pattern but differ by Host, Headers, Queries, or
+// Matchers.
+type routeAlternative struct {
+	matchers []func(*http.Request) bool
+	handler  Handler
+}
+
+// matches reports whether every matcher predicate passes for r. An
+// alternative with no matchers always matches, which is how plain routes
+// (registered via Handle or a matcher-less Route) behave.
+func (a routeAlternative) matches(r *http.Request) bool {
+	for _, m := range a.matchers {
+		if !m(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// compileMatchers converts a Route's declarative Host/Headers/Queries/
+// Matchers fields into the predicate functions evaluated against each
+// incoming request, in that order, followed by Matchers itself.
+func compileMatchers(r Route) []func(*http.Request) bool {
+	var matchers []func(*http.Request) bool
+
+	if r.Host != "" {
+		host := r.Host
+		matchers = append(matchers, func(req *http.Request) bool { return req.Host == host })
+	}
+
+	for key, val := range r.Headers {
+		key, val := key, val
+		matchers = append(matchers, func(req *http.Request) bool { return req.Header.Get(key) == val })
+	}
+
+	for key, val := range r.Queries {
+		key, val := key, val
+		matchers = append(matchers, func(req *http.Request) bool { return req.URL.Query().Get(key) == val })
+	}
+
+	return append(matchers, r.Matchers...)
+}
@@ -0,0 +1,161 @@
+package servemux
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestServeMux_RouteMatchers_Host(t *testing.T) {
+	mux := New()
+	mux.Route(Route{
+		Pattern: "GET /",
+		Host:    "tenant-a.example.com",
+		Handler: func(w http.ResponseWriter, r *http.Request) error {
+			_, _ = w.Write([]byte("tenant-a"))
+			return nil
+		},
+	})
+	mux.Route(Route{
+		Pattern: "GET /",
+		Host:    "tenant-b.example.com",
+		Handler: func(w http.ResponseWriter, r *http.Request) error {
+			_, _ = w.Write([]byte("tenant-b"))
+			return nil
+		},
+	})
+
+	t.Run("tenant-a host wins its route", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "http://tenant-a.example.com/", nil)
+		res := httptest.NewRecorder()
+		mux.ServeHTTP(res, req)
+		expectTrue(t, res.Body.String() == "tenant-a")
+	})
+
+	t.Run("tenant-b host wins its route", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "http://tenant-b.example.com/", nil)
+		res := httptest.NewRecorder()
+		mux.ServeHTTP(res, req)
+		expectTrue(t, res.Body.String() == "tenant-b")
+	})
+
+	t.Run("unmatched host falls back to miss handler", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "http://unknown.example.com/", nil)
+		res := httptest.NewRecorder()
+		mux.ServeHTTP(res, req)
+		expectTrue(t, res.Code == http.StatusNotFound)
+	})
+}
+
+func TestServeMux_RouteMatchers_Headers(t *testing.T) {
+	mux := New()
+	mux.Route(Route{
+		Pattern: "GET /data",
+		Headers: map[string]string{"Accept": "application/json"},
+		Handler: func(w http.ResponseWriter, r *http.Request) error {
+			w.WriteHeader(200)
+			return nil
+		},
+	})
+	mux.Route(Route{
+		Pattern: "GET /data",
+		Headers: map[string]string{"Accept": "text/plain"},
+		Handler: func(w http.ResponseWriter, r *http.Request) error {
+			w.WriteHeader(206)
+			return nil
+		},
+	})
+
+	t.Run("json accept header", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/data", nil)
+		req.Header.Set("Accept", "application/json")
+		res := httptest.NewRecorder()
+		mux.ServeHTTP(res, req)
+		expectTrue(t, res.Code == 200)
+	})
+
+	t.Run("text accept header", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/data", nil)
+		req.Header.Set("Accept", "text/plain")
+		res := httptest.NewRecorder()
+		mux.ServeHTTP(res, req)
+		expectTrue(t, res.Code == 206)
+	})
+}
+
+func TestServeMux_RouteMatchers_Queries(t *testing.T) {
+	mux := New()
+	mux.Route(Route{
+		Pattern: "GET /search",
+		Queries: map[string]string{"beta": "true"},
+		Handler: func(w http.ResponseWriter, r *http.Request) error {
+			w.WriteHeader(200)
+			return nil
+		},
+	})
+
+	t.Run("matching query", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/search?beta=true", nil)
+		res := httptest.NewRecorder()
+		mux.ServeHTTP(res, req)
+		expectTrue(t, res.Code == 200)
+	})
+
+	t.Run("missing query falls back to miss handler", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/search", nil)
+		res := httptest.NewRecorder()
+		mux.ServeHTTP(res, req)
+		expectTrue(t, res.Code == http.StatusNotFound)
+	})
+}
+
+func TestServeMux_Routes_ReflectMatchers(t *testing.T) {
+	mux := New()
+	mux.Route(Route{
+		Pattern: "GET /data",
+		Host:    "tenant-a.example.com",
+		Headers: map[string]string{"Accept": "application/json"},
+		Queries: map[string]string{"beta": "true"},
+		Handler: func(w http.ResponseWriter, r *http.Request) error { return nil },
+	})
+	mux.Route(Route{
+		Pattern: "GET /data",
+		Host:    "tenant-b.example.com",
+		Handler: func(w http.ResponseWriter, r *http.Request) error { return nil },
+	})
+
+	routes := mux.Routes()
+	expectTrue(t, len(routes) == 2)
+	expectTrue(t, routes[0].Host == "tenant-a.example.com")
+	expectTrue(t, routes[0].Headers["Accept"] == "application/json")
+	expectTrue(t, routes[0].Queries["beta"] == "true")
+	expectTrue(t, routes[1].Host == "tenant-b.example.com")
+}
+
+func TestServeMux_Handle_DuplicatePatternWithoutMatchers_Panics(t *testing.T) {
+	defer func() {
+		expectTrue(t, recover() != nil)
+	}()
+
+	mux := New()
+	mux.HandleFunc("GET /data", noopHandler)
+	mux.HandleFunc("GET /data", noopHandler)
+}
+
+func TestServeMux_SetMatchMissHandler(t *testing.T) {
+	mux := New()
+	mux.SetMatchMissHandler(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+
+	mux.Route(Route{
+		Pattern: "GET /data",
+		Host:    "only.example.com",
+		Handler: func(w http.ResponseWriter, r *http.Request) error { return nil },
+	})
+
+	req := httptest.NewRequest("GET", "/data", nil)
+	res := httptest.NewRecorder()
+	mux.ServeHTTP(res, req)
+	expectTrue(t, res.Code == http.StatusTeapot)
+}
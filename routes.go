@@ -0,0 +1,24 @@
+package servemux
+
+// Walk calls fn for each route registered so far, in registration order,
+// analogous to gorilla/mux's Router.Walk. If fn returns a non-nil error,
+// Walk stops traversal immediately and returns that error.
+func (mux *ServeMux) Walk(fn func(pattern string, handler Handler) error) error {
+	for _, rt := range mux.routes {
+		if err := fn(rt.Pattern, rt.Handler); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Routes returns a snapshot of every route registered so far, in
+// registration order. This includes routes registered through a Group, with
+// their prefix already applied. It is intended for building OpenAPI
+// generators, printing a route table at startup, or asserting in tests that
+// a given pattern was registered.
+func (mux *ServeMux) Routes() []Route {
+	out := make([]Route, len(mux.routes))
+	copy(out, mux.routes)
+	return out
+}
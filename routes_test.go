@@ -0,0 +1,64 @@
+package servemux
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func noopHandler(w http.ResponseWriter, r *http.Request) error { return nil }
+
+func TestServeMux_Routes(t *testing.T) {
+	mux := New()
+	mux.HandleFunc("GET /data", noopHandler)
+	mux.HandleFunc("POST /data/{id}", noopHandler)
+
+	api := mux.Group("/api")
+	api.HandleFunc("GET /users", noopHandler)
+
+	routes := mux.Routes()
+	expectTrue(t, len(routes) == 3)
+	expectTrue(t, routes[0].Method == "GET" && routes[0].Path == "/data")
+	expectTrue(t, routes[1].Method == "POST" && routes[1].Path == "/data/{id}")
+	expectTrue(t, routes[2].Pattern == "GET /api/users")
+	expectTrue(t, routes[2].Method == "GET" && routes[2].Path == "/api/users")
+}
+
+func TestServeMux_Walk(t *testing.T) {
+	mux := New()
+	mux.HandleFunc("GET /one", noopHandler)
+	mux.HandleFunc("GET /two", noopHandler)
+	mux.HandleFunc("GET /three", noopHandler)
+
+	var seen []string
+	err := mux.Walk(func(pattern string, handler Handler) error {
+		seen = append(seen, pattern)
+		return nil
+	})
+	expectTrue(t, err == nil)
+	expectTrue(t, len(seen) == 3)
+	expectTrue(t, seen[0] == "GET /one")
+	expectTrue(t, seen[1] == "GET /two")
+	expectTrue(t, seen[2] == "GET /three")
+}
+
+func TestServeMux_Walk_StopsOnError(t *testing.T) {
+	stop := errors.New("stop walking")
+
+	mux := New()
+	mux.HandleFunc("GET /one", noopHandler)
+	mux.HandleFunc("GET /two", noopHandler)
+	mux.HandleFunc("GET /three", noopHandler)
+
+	var seen []string
+	err := mux.Walk(func(pattern string, handler Handler) error {
+		seen = append(seen, pattern)
+		if pattern == "GET /two" {
+			return stop
+		}
+		return nil
+	})
+
+	expectTrue(t, errors.Is(err, stop))
+	expectTrue(t, len(seen) == 2)
+}
@@ -1,6 +1,7 @@
 package servemux
 
 import (
+	"fmt"
 	"net/http"
 )
 
@@ -50,6 +51,27 @@ type LastResortErrorHandler func(http.ResponseWriter, *http.Request, error)
 type Route struct {
 	Pattern string
 	Handler HandlerFunc
+
+	// Host, Headers, Queries, and Matchers optionally restrict which requests
+	// this Route handles, modeled on gorilla/mux's Host/Headers/Queries/
+	// MatcherFunc. They are evaluated, in the order described on
+	// compileMatchers, before the middleware chain runs. Multiple Routes may
+	// share the same Pattern as long as their matchers are mutually
+	// exclusive; the first registered Route whose matchers all pass wins. If
+	// none match, the mux falls back to its configured miss handler (see
+	// SetMatchMissHandler).
+	Host     string
+	Headers  map[string]string
+	Queries  map[string]string
+	Matchers []func(*http.Request) bool
+
+	// Method and Path are populated by ServeMux when the route is registered,
+	// decomposed from Pattern using the Go 1.22 ServeMux pattern syntax (e.g.
+	// "POST /data/{id}" becomes Method "POST" and Path "/data/{id}"). Callers
+	// registering a route do not need to set these; read them back via
+	// Routes.
+	Method string
+	Path   string
 }
 
 // ServeMux is a wrapper of net/http.ServeMux with modified Handler.
@@ -64,14 +86,40 @@ type ServeMux struct {
 	//
 	// This handler is not part of the httprouter.Router, it is used by the ServeMux.
 	lastResort LastResortErrorHandler
+
+	// errorEncoders is the chain of typed error-to-HTTP mappings consulted by
+	// Handle before falling back to lastResort. See RegisterErrorEncoder and
+	// OnError.
+	errorEncoders []ErrorEncoder
+
+	// negotiator writes a matched ErrorEncoder's status and body to the
+	// response. See SetContentTypeNegotiator.
+	negotiator ContentTypeNegotiator
+
+	// routes records every route registered via Handle, HandleFunc, Route,
+	// or a Group, in registration order. See Routes and Walk.
+	routes []Route
+
+	// alternatives holds, per pattern, the matcher-guarded handlers
+	// registered for it, in registration order. A pattern with a single
+	// alternative and no matchers behaves exactly as before; http.ServeMux
+	// only ever sees one HandleFunc call per pattern. See SetMatchMissHandler.
+	alternatives map[string][]routeAlternative
+
+	// missHandler is invoked when a pattern has matcher-guarded alternatives
+	// but none of them match the incoming request. See SetMatchMissHandler.
+	missHandler http.HandlerFunc
 }
 
 // New creates a new ServeMux.
 func New() *ServeMux {
 	mux := ServeMux{
-		core:       http.NewServeMux(),
-		midl:       func(h Handler) Handler { return h },
-		lastResort: nil,
+		core:          http.NewServeMux(),
+		midl:          func(h Handler) Handler { return h },
+		lastResort:    nil,
+		errorEncoders: []ErrorEncoder{statusErrorEncoder},
+		negotiator:    defaultNegotiator,
+		missHandler:   http.NotFound,
 	}
 
 	return &mux
@@ -91,11 +139,21 @@ func (mux *ServeMux) SetLastResortErrorHandler(h LastResortErrorHandler) {
 	}
 }
 
+// SetMatchMissHandler sets the handler invoked when a pattern has
+// matcher-guarded alternatives (see Route.Host, Route.Headers, Route.Queries,
+// Route.Matchers) but none of them match the incoming request. The default
+// responds with 404 Not Found.
+func (mux *ServeMux) SetMatchMissHandler(h http.HandlerFunc) {
+	if h != nil {
+		mux.missHandler = h
+	}
+}
+
 // Route is a syntactic sugar for Handle(method, path, handler) by using Route struct.
 // This route also accepts variadic Middleware, which is applied to the route handler.
 func (mux *ServeMux) Route(r Route, middlewares ...Middleware) {
 	chain := foldMiddlewares(middlewares)
-	mux.Handle(r.Pattern, chain.Then(r.Handler))
+	mux.register(r, chain.Then(r.Handler), compileMatchers(r))
 }
 
 // HandleFunc just like Handle, but it accepts HandlerFunc.
@@ -105,15 +163,60 @@ func (mux *ServeMux) HandleFunc(pattern string, handler HandlerFunc) {
 
 // Handle registers a new request handler with the given method and path.
 func (mux *ServeMux) Handle(pattern string, handler Handler) {
-	mux.core.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
-		err := mux.midl.Then(handler).ServeHTTP(w, r)
-		if err != nil {
-			if mux.lastResort != nil {
-				mux.lastResort(w, r, err)
-			} else {
-				http.Error(w, err.Error(), http.StatusInternalServerError)
+	mux.register(Route{Pattern: pattern}, handler, nil)
+}
+
+// register records meta (including its Host/Headers/Queries/Matchers, so
+// Routes and Walk can report them) for introspection, then wires wrapped
+// into the underlying http.ServeMux, guarded by matchers if any are given.
+// It is the shared implementation behind Handle and the matcher-aware
+// Route.
+//
+// Registering a pattern with no matchers when it already has an alternative
+// with no matchers panics, mirroring
+// http.ServeMux's own duplicate-pattern check; it typically means two
+// routes were meant to be distinguished by a matcher and one was left off
+// by mistake. Registering with matchers is allowed, since that is exactly
+// how multiple Routes are meant to share a pattern.
+func (mux *ServeMux) register(meta Route, wrapped Handler, matchers []func(*http.Request) bool) {
+	method, path := splitPattern(meta.Pattern)
+	stored := meta
+	stored.Handler = HandlerFunc(wrapped.ServeHTTP)
+	stored.Method = method
+	stored.Path = path
+	mux.routes = append(mux.routes, stored)
+
+	if mux.alternatives == nil {
+		mux.alternatives = make(map[string][]routeAlternative)
+	}
+
+	existing := mux.alternatives[meta.Pattern]
+	if len(matchers) == 0 {
+		for _, alt := range existing {
+			if len(alt.matchers) == 0 {
+				panic(fmt.Sprintf("servemux: pattern %q registered more than once with no matchers", meta.Pattern))
+			}
+		}
+	}
+	mux.alternatives[meta.Pattern] = append(existing, routeAlternative{
+		matchers: matchers,
+		handler:  wrapped,
+	})
+	if len(existing) > 0 {
+		return
+	}
+
+	mux.core.HandleFunc(meta.Pattern, func(w http.ResponseWriter, r *http.Request) {
+		for _, alt := range mux.alternatives[meta.Pattern] {
+			if !alt.matches(r) {
+				continue
+			}
+			if err := mux.midl.Then(alt.handler).ServeHTTP(w, r); err != nil {
+				mux.handleError(w, r, err)
 			}
+			return
 		}
+		mux.missHandler(w, r)
 	})
 }
 
@@ -0,0 +1,112 @@
+package servemux
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// Server wraps an *http.Server around a ServeMux, adding the graceful
+// shutdown boilerplate (draining in-flight requests, running cleanup hooks,
+// reacting to OS signals) that would otherwise be rewritten in every
+// service built on top of http.ListenAndServe.
+type Server struct {
+	mux   *ServeMux
+	http  *http.Server
+	grace time.Duration
+	hooks []func(context.Context) error
+}
+
+// NewServer creates a Server that serves mux, installing it as the handler
+// of the underlying *http.Server. When the context passed to ListenAndServe
+// is canceled, the server stops accepting new connections and waits up to
+// grace for in-flight requests to finish before returning.
+func NewServer(mux *ServeMux, grace time.Duration) *Server {
+	return &Server{
+		mux:   mux,
+		http:  &http.Server{Handler: mux},
+		grace: grace,
+	}
+}
+
+// OnShutdown registers a hook run, in registration order, once the server
+// has stopped accepting new connections. Hooks share the shutdown's grace
+// period and are typically used to close database connections or flush
+// logs.
+func (s *Server) OnShutdown(hook func(context.Context) error) {
+	s.hooks = append(s.hooks, hook)
+}
+
+// WithReadiness registers a handler at path that responds 200 OK, suitable
+// for a Kubernetes readinessProbe or load balancer health check.
+func (s *Server) WithReadiness(path string) *Server {
+	return s.withHealthCheck(path)
+}
+
+// WithLiveness registers a handler at path that responds 200 OK, suitable
+// for a Kubernetes livenessProbe.
+func (s *Server) WithLiveness(path string) *Server {
+	return s.withHealthCheck(path)
+}
+
+// withHealthCheck registers a handler at path that always responds 200 OK,
+// the shared implementation behind WithReadiness and WithLiveness.
+func (s *Server) withHealthCheck(path string) *Server {
+	s.mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) error {
+		w.WriteHeader(http.StatusOK)
+		return nil
+	})
+	return s
+}
+
+// ListenAndServe starts serving addr and blocks until ctx is canceled or the
+// server fails to start. On cancellation, it gracefully shuts down the
+// underlying *http.Server within the configured grace period, then runs any
+// hooks registered with OnShutdown.
+func (s *Server) ListenAndServe(ctx context.Context, addr string) error {
+	s.http.Addr = addr
+
+	serveErr := make(chan error, 1)
+	go func() {
+		if err := s.http.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serveErr <- err
+			return
+		}
+		serveErr <- nil
+	}()
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.grace)
+	defer cancel()
+
+	err := s.http.Shutdown(shutdownCtx)
+	for _, hook := range s.hooks {
+		if hookErr := hook(shutdownCtx); hookErr != nil {
+			err = errors.Join(err, hookErr)
+		}
+	}
+	return err
+}
+
+// RunUntilSignal is a convenience wrapper around ListenAndServe that builds
+// a context canceled when any of signals is received, defaulting to
+// SIGINT and SIGTERM if none are given.
+func (s *Server) RunUntilSignal(addr string, signals ...os.Signal) error {
+	if len(signals) == 0 {
+		signals = []os.Signal{syscall.SIGINT, syscall.SIGTERM}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), signals...)
+	defer stop()
+
+	return s.ListenAndServe(ctx, addr)
+}
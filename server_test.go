@@ -0,0 +1,42 @@
+package servemux
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestServer_ListenAndServe_GracefulShutdown(t *testing.T) {
+	mux := New()
+	mux.HandleFunc("GET /data", func(w http.ResponseWriter, r *http.Request) error {
+		w.WriteHeader(200)
+		return nil
+	})
+
+	srv := NewServer(mux, 2*time.Second)
+
+	var hookCalled bool
+	srv.OnShutdown(func(ctx context.Context) error {
+		hookCalled = true
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := srv.ListenAndServe(ctx, "127.0.0.1:0")
+	expectTrue(t, err == nil)
+	expectTrue(t, hookCalled)
+}
+
+func TestServer_WithReadinessAndLiveness(t *testing.T) {
+	mux := New()
+	srv := NewServer(mux, time.Second).WithReadiness("/readyz").WithLiveness("/livez")
+	expectTrue(t, srv != nil)
+
+	routes := mux.Routes()
+	expectTrue(t, len(routes) == 2)
+	expectTrue(t, routes[0].Pattern == "/readyz")
+	expectTrue(t, routes[1].Pattern == "/livez")
+}